@@ -0,0 +1,147 @@
+// Package alertstate tracks the lifecycle of each (validator, rule) alert
+// condition over time so that callers can apply Prometheus-style hysteresis
+// ("for" durations) and avoid re-firing on every polling interval.
+package alertstate
+
+import (
+	"fmt"
+	"time"
+)
+
+// State is the current lifecycle stage of an alert condition.
+type State string
+
+const (
+	StateOK        State = "OK"
+	StatePending   State = "PENDING"
+	StateFiring    State = "FIRING"
+	StateResolving State = "RESOLVING"
+	StateResolved  State = "RESOLVED"
+)
+
+// Entry is the persisted state for one (validator, rule) pair.
+type Entry struct {
+	Key          string    `json:"key"`
+	State        State     `json:"state"`
+	FirstTrigger time.Time `json:"first_trigger"`
+	LastTrigger  time.Time `json:"last_trigger"`
+	FirstClear   time.Time `json:"first_clear"`
+	LastResolved time.Time `json:"last_resolved"`
+	LastRepeat   time.Time `json:"last_repeat"`
+	DedupKey     string    `json:"dedup_key"`
+}
+
+// Transition describes what a Manager wants the caller to do as the result
+// of evaluating a condition: nothing, fire a new trigger, repeat an
+// already-firing alert (for services like PagerDuty that expect periodic
+// reminders), or resolve a previously-firing one.
+type Transition int
+
+const (
+	TransitionNone Transition = iota
+	TransitionTrigger
+	TransitionRepeat
+	TransitionResolve
+)
+
+// Store persists Entry values so alert state survives process restarts.
+// MemStore (the default) satisfies this with no persistence; BoltStore
+// backs it with a BoltDB file.
+type Store interface {
+	Load(key string) (Entry, bool)
+	Save(entry Entry) error
+}
+
+// Manager evaluates a boolean condition per key (typically
+// "<validator>/<rule>") and applies hysteresis before reporting a
+// transition. forDuration, repeatInterval and resolveAfter are passed
+// per-call rather than fixed at construction, since each rule may declare
+// its own `for` and `resolve_after`.
+type Manager struct {
+	store Store
+}
+
+func NewManager(store Store) *Manager {
+	if store == nil {
+		store = NewMemStore()
+	}
+	return &Manager{store: store}
+}
+
+// Evaluate records whether the alert condition for key currently holds and
+// returns what the caller should do about it. now is passed in (rather than
+// read from time.Now internally) so callers can drive evaluation from a
+// single consistent tick timestamp. Mirroring forDuration's PENDING
+// hysteresis on the way up, resolveAfter requires the condition to stay
+// clear continuously for that long before a FIRING alert is reported
+// resolved, so a single clean tick can't flap it straight to RESOLVED.
+func (m *Manager) Evaluate(key string, holds bool, now time.Time, forDuration, repeatInterval, resolveAfter time.Duration) (Transition, Entry) {
+	entry, ok := m.store.Load(key)
+	if !ok {
+		entry = Entry{Key: key, State: StateOK}
+	}
+
+	transition := TransitionNone
+
+	fire := func() {
+		entry.State = StateFiring
+		entry.LastTrigger = now
+		entry.LastRepeat = now
+		entry.DedupKey = fmt.Sprintf("%s-%d", key, entry.FirstTrigger.Unix())
+		transition = TransitionTrigger
+	}
+
+	switch {
+	case holds && entry.State == StateOK:
+		entry.State = StatePending
+		entry.FirstTrigger = now
+
+	case holds && entry.State == StatePending:
+		if now.Sub(entry.FirstTrigger) >= forDuration {
+			fire()
+		}
+
+	case holds && entry.State == StateFiring:
+		if repeatInterval > 0 && now.Sub(entry.LastRepeat) >= repeatInterval {
+			entry.LastRepeat = now
+			transition = TransitionRepeat
+		}
+
+	case holds && entry.State == StateResolving:
+		// The condition came back before the cooldown elapsed: it never
+		// really cleared, so go back to FIRING without re-triggering.
+		entry.State = StateFiring
+		entry.FirstClear = time.Time{}
+
+	case !holds && entry.State == StateFiring:
+		entry.State = StateResolving
+		entry.FirstClear = now
+
+	case !holds && entry.State == StateResolving:
+		if now.Sub(entry.FirstClear) >= resolveAfter {
+			entry.State = StateResolved
+			entry.LastResolved = now
+			transition = TransitionResolve
+		}
+
+	case !holds && (entry.State == StatePending || entry.State == StateResolved):
+		entry.State = StateOK
+		entry.DedupKey = ""
+
+	case holds && entry.State == StateResolved:
+		// The condition flapped back before anything observed it go back
+		// to OK: re-arm exactly like the StateOK case, rather than leaving
+		// the entry stuck in RESOLVED forever.
+		entry.State = StatePending
+		entry.FirstTrigger = now
+	}
+
+	// With no `for` duration there is nothing to wait out: a condition that
+	// just entered PENDING fires on this same tick.
+	if transition == TransitionNone && entry.State == StatePending && forDuration <= 0 {
+		fire()
+	}
+
+	_ = m.store.Save(entry)
+	return transition, entry
+}
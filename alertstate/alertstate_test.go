@@ -0,0 +1,106 @@
+package alertstate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEvaluateLifecycle(t *testing.T) {
+	m := NewManager(nil)
+	key := "validator/rule"
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	forDuration := 2 * time.Minute
+	repeatInterval := time.Duration(0)
+	resolveAfter := time.Minute
+
+	// Condition starts holding: OK -> PENDING, no transition yet.
+	transition, entry := m.Evaluate(key, true, t0, forDuration, repeatInterval, resolveAfter)
+	if transition != TransitionNone || entry.State != StatePending {
+		t.Fatalf("first tick: got transition=%v state=%v, want TransitionNone/PENDING", transition, entry.State)
+	}
+
+	// Still holding but `for` hasn't elapsed: stays PENDING.
+	transition, entry = m.Evaluate(key, true, t0.Add(time.Minute), forDuration, repeatInterval, resolveAfter)
+	if transition != TransitionNone || entry.State != StatePending {
+		t.Fatalf("mid-for tick: got transition=%v state=%v, want TransitionNone/PENDING", transition, entry.State)
+	}
+
+	// `for` has now elapsed: PENDING -> FIRING, TransitionTrigger.
+	firingAt := t0.Add(3 * time.Minute)
+	transition, entry = m.Evaluate(key, true, firingAt, forDuration, repeatInterval, resolveAfter)
+	if transition != TransitionTrigger || entry.State != StateFiring {
+		t.Fatalf("for-elapsed tick: got transition=%v state=%v, want TransitionTrigger/FIRING", transition, entry.State)
+	}
+
+	// Condition clears: FIRING -> RESOLVING, no transition yet.
+	clearedAt := firingAt.Add(time.Second)
+	transition, entry = m.Evaluate(key, false, clearedAt, forDuration, repeatInterval, resolveAfter)
+	if transition != TransitionNone || entry.State != StateResolving {
+		t.Fatalf("clear tick: got transition=%v state=%v, want TransitionNone/RESOLVING", transition, entry.State)
+	}
+
+	// Condition flaps back before resolveAfter elapses: RESOLVING -> FIRING, not re-triggered.
+	transition, entry = m.Evaluate(key, true, clearedAt.Add(time.Second), forDuration, repeatInterval, resolveAfter)
+	if transition != TransitionNone || entry.State != StateFiring {
+		t.Fatalf("flap-during-resolving tick: got transition=%v state=%v, want TransitionNone/FIRING", transition, entry.State)
+	}
+
+	// Clears again and stays clear long enough to resolve.
+	clearedAt = clearedAt.Add(2 * time.Second)
+	m.Evaluate(key, false, clearedAt, forDuration, repeatInterval, resolveAfter)
+	transition, entry = m.Evaluate(key, false, clearedAt.Add(resolveAfter), forDuration, repeatInterval, resolveAfter)
+	if transition != TransitionResolve || entry.State != StateResolved {
+		t.Fatalf("resolve-after-elapsed tick: got transition=%v state=%v, want TransitionResolve/RESOLVED", transition, entry.State)
+	}
+
+	// Condition flaps back to true after reaching RESOLVED: must re-arm into
+	// PENDING rather than staying stuck in RESOLVED forever.
+	reTriggerAt := clearedAt.Add(resolveAfter).Add(time.Second)
+	transition, entry = m.Evaluate(key, true, reTriggerAt, forDuration, repeatInterval, resolveAfter)
+	if transition != TransitionNone || entry.State != StatePending {
+		t.Fatalf("re-trigger-after-resolved tick: got transition=%v state=%v, want TransitionNone/PENDING", transition, entry.State)
+	}
+	if !entry.FirstTrigger.Equal(reTriggerAt) {
+		t.Fatalf("re-trigger-after-resolved tick: FirstTrigger = %v, want %v", entry.FirstTrigger, reTriggerAt)
+	}
+
+	// And it can fire again from there.
+	transition, entry = m.Evaluate(key, true, reTriggerAt.Add(forDuration), forDuration, repeatInterval, resolveAfter)
+	if transition != TransitionTrigger || entry.State != StateFiring {
+		t.Fatalf("re-fire tick: got transition=%v state=%v, want TransitionTrigger/FIRING", transition, entry.State)
+	}
+}
+
+func TestEvaluateClearsBeforeForElapsed(t *testing.T) {
+	m := NewManager(nil)
+	key := "validator/rule"
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	m.Evaluate(key, true, t0, time.Minute, 0, time.Minute)
+	transition, entry := m.Evaluate(key, false, t0.Add(time.Second), time.Minute, 0, time.Minute)
+	if transition != TransitionNone || entry.State != StateOK {
+		t.Fatalf("clear-before-for tick: got transition=%v state=%v, want TransitionNone/OK", transition, entry.State)
+	}
+}
+
+func TestEvaluateRepeatInterval(t *testing.T) {
+	m := NewManager(nil)
+	key := "validator/rule"
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	_, entry := m.Evaluate(key, true, t0, 0, time.Minute, time.Minute)
+	if entry.State != StateFiring {
+		t.Fatalf("immediate-fire tick: got state=%v, want FIRING", entry.State)
+	}
+
+	transition, _ := m.Evaluate(key, true, t0.Add(30*time.Second), 0, time.Minute, time.Minute)
+	if transition != TransitionNone {
+		t.Fatalf("before-repeat-interval tick: got transition=%v, want TransitionNone", transition)
+	}
+
+	transition, _ = m.Evaluate(key, true, t0.Add(time.Minute), 0, time.Minute, time.Minute)
+	if transition != TransitionRepeat {
+		t.Fatalf("repeat-interval-elapsed tick: got transition=%v, want TransitionRepeat", transition)
+	}
+}
@@ -0,0 +1,69 @@
+package alertstate
+
+import (
+	"encoding/json"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+var bucketName = []byte("alertstate")
+
+// BoltStore persists Entry values to a BoltDB file so alert state (and in
+// particular which alerts are currently FIRING) survives a process
+// restart, avoiding duplicate PagerDuty incidents on redeploy.
+type BoltStore struct {
+	db *bolt.DB
+}
+
+// OpenBoltStore opens (creating if necessary) a BoltDB file at path.
+func OpenBoltStore(path string) (*BoltStore, error) {
+	db, err := bolt.Open(path, 0o600, nil)
+	if err != nil {
+		return nil, fmt.Errorf("alertstate: open bolt db: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucketName)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("alertstate: create bucket: %w", err)
+	}
+
+	return &BoltStore{db: db}, nil
+}
+
+func (b *BoltStore) Load(key string) (Entry, bool) {
+	var entry Entry
+	var found bool
+
+	_ = b.db.View(func(tx *bolt.Tx) error {
+		raw := tx.Bucket(bucketName).Get([]byte(key))
+		if raw == nil {
+			return nil
+		}
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return err
+		}
+		found = true
+		return nil
+	})
+
+	return entry, found
+}
+
+func (b *BoltStore) Save(entry Entry) error {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("alertstate: marshal entry: %w", err)
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketName).Put([]byte(entry.Key), raw)
+	})
+}
+
+func (b *BoltStore) Close() error {
+	return b.db.Close()
+}
@@ -0,0 +1,28 @@
+package alertstate
+
+import "sync"
+
+// MemStore is an in-memory Store. It is the default when no BoltDB path is
+// configured and is always used in tests.
+type MemStore struct {
+	mu      sync.Mutex
+	entries map[string]Entry
+}
+
+func NewMemStore() *MemStore {
+	return &MemStore{entries: make(map[string]Entry)}
+}
+
+func (m *MemStore) Load(key string) (Entry, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.entries[key]
+	return entry, ok
+}
+
+func (m *MemStore) Save(entry Entry) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries[entry.Key] = entry
+	return nil
+}
@@ -0,0 +1,83 @@
+package logtail
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+)
+
+// findLatestLogFile walks basePath/<latest date dir>/<latest numbered file>,
+// matching the layout HyperLiquid writes its per-hour consensus logs into.
+func findLatestLogFile(basePath string) (string, error) {
+	latestDateDir, err := findLatestDir(basePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to find latest date directory: %w", err)
+	}
+
+	latestLogFile, err := findLatestFile(latestDateDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to find latest log file: %w", err)
+	}
+
+	return latestLogFile, nil
+}
+
+func findLatestDir(basePath string) (string, error) {
+	entries, err := os.ReadDir(basePath)
+	if err != nil {
+		return "", err
+	}
+
+	var dirs []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			dirs = append(dirs, entry.Name())
+		}
+	}
+
+	if len(dirs) == 0 {
+		return "", fmt.Errorf("no directories found in %s", basePath)
+	}
+
+	latestDir := dirs[0]
+	for _, dir := range dirs {
+		if dir > latestDir {
+			latestDir = dir
+		}
+	}
+
+	return fmt.Sprintf("%s/%s", basePath, latestDir), nil
+}
+
+func findLatestFile(dirPath string) (string, error) {
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return "", err
+	}
+
+	var files []string
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			files = append(files, entry.Name())
+		}
+	}
+
+	if len(files) == 0 {
+		return "", fmt.Errorf("no files found in %s", dirPath)
+	}
+
+	// Sort files to ensure correct order
+	sort.Slice(files, func(i, j int) bool {
+		iInt, errI := strconv.Atoi(files[i])
+		jInt, errJ := strconv.Atoi(files[j])
+		if errI == nil && errJ == nil {
+			return iInt < jInt
+		}
+		return files[i] < files[j]
+	})
+
+	latestFile := files[len(files)-1]
+
+	return fmt.Sprintf("%s/%s", dirPath, latestFile), nil
+}
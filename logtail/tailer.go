@@ -0,0 +1,250 @@
+// Package logtail streams new JSON lines appended to HyperLiquid's
+// hourly-rotating consensus logs, instead of re-reading and re-decoding the
+// entire file on every poll.
+package logtail
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/rs/zerolog"
+)
+
+// Tailer follows the latest numbered log file under basePath, switching to
+// new files and date directories as they appear and re-opening the current
+// file if it is truncated.
+type Tailer struct {
+	basePath string
+	logger   zerolog.Logger
+
+	watcher     *fsnotify.Watcher
+	currentPath string
+	file        *os.File
+	reader      *bufio.Reader
+	offset      int64
+}
+
+// NewTailer opens the latest log file under basePath, seeks to EOF, and
+// starts watching basePath (and the directory holding the current file) for
+// new files/directories. logger is used to emit the "file_rotated" event
+// whenever the tailer switches to a new file.
+func NewTailer(basePath string, logger zerolog.Logger) (*Tailer, error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("logtail: new watcher: %w", err)
+	}
+	if err := watcher.Add(basePath); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("logtail: watch %s: %w", basePath, err)
+	}
+
+	t := &Tailer{basePath: basePath, logger: logger, watcher: watcher}
+
+	path, err := findLatestLogFile(basePath)
+	if err != nil {
+		watcher.Close()
+		return nil, err
+	}
+	if err := t.switchTo(path, true); err != nil {
+		watcher.Close()
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Close releases the underlying fsnotify watcher and open file handle.
+func (t *Tailer) Close() error {
+	if t.file != nil {
+		t.file.Close()
+	}
+	return t.watcher.Close()
+}
+
+// switchTo opens path as the new current file. If seekEOF is true (initial
+// open, or a brand-new file discovered mid-run) the tailer starts reading
+// only entries appended from this point on.
+func (t *Tailer) switchTo(path string, seekEOF bool) error {
+	rotated := t.file != nil
+	if t.file != nil {
+		t.file.Close()
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("logtail: open %s: %w", path, err)
+	}
+
+	var offset int64
+	if seekEOF {
+		offset, err = file.Seek(0, io.SeekEnd)
+		if err != nil {
+			file.Close()
+			return fmt.Errorf("logtail: seek %s: %w", path, err)
+		}
+	}
+
+	if err := t.watcher.Add(dirOf(path)); err != nil {
+		file.Close()
+		return fmt.Errorf("logtail: watch %s: %w", dirOf(path), err)
+	}
+
+	previousPath := t.currentPath
+	t.file = file
+	t.reader = bufio.NewReader(file)
+	t.currentPath = path
+	t.offset = offset
+
+	if rotated {
+		t.logger.Info().
+			Str("event", "file_rotated").
+			Str("file", path).
+			Str("previous_file", previousPath).
+			Msg("switched to new log file")
+	}
+	return nil
+}
+
+// Run streams decoded log lines to entries until ctx is cancelled. Decode
+// and I/O errors are sent to errs rather than aborting the tail.
+func (t *Tailer) Run(ctx context.Context, entries chan<- LogArrayEntry, errs chan<- error) {
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+
+		case event, ok := <-t.watcher.Events:
+			if !ok {
+				return
+			}
+			t.handleEvent(event, entries, errs)
+
+		case err, ok := <-t.watcher.Errors:
+			if !ok {
+				return
+			}
+			errs <- fmt.Errorf("logtail: watcher error: %w", err)
+
+		case <-ticker.C:
+			// fsnotify can coalesce or miss events under heavy rotation (an
+			// inotify queue overflow surfaces as a watcher error, not a
+			// missed Create): periodically re-resolving "latest" alongside
+			// the drain keeps the tailer self-healing even if a rotation
+			// event never arrives.
+			t.drain(entries, errs)
+			t.checkRotation(entries, errs)
+		}
+	}
+}
+
+func (t *Tailer) handleEvent(event fsnotify.Event, entries chan<- LogArrayEntry, errs chan<- error) {
+	switch {
+	case event.Op&(fsnotify.Write) != 0 && event.Name == t.currentPath:
+		t.drain(entries, errs)
+
+	case event.Op&(fsnotify.Create) != 0:
+		// Either a new numbered file in the current directory, or a new
+		// date directory: re-resolve "latest" and switch if it changed.
+		if fi, err := os.Stat(event.Name); err == nil && fi.IsDir() {
+			if err := t.watcher.Add(event.Name); err != nil {
+				errs <- fmt.Errorf("logtail: watch new dir %s: %w", event.Name, err)
+			}
+		}
+
+		t.checkRotation(entries, errs)
+	}
+}
+
+// checkRotation re-resolves the latest log file under basePath and, if it
+// differs from the one the tailer currently has open, flushes what's left
+// of the old file and switches over. Called both on an fsnotify Create
+// event and periodically from Run's ticker, so a rotation still takes
+// effect even if its Create event is lost (e.g. an inotify queue overflow).
+func (t *Tailer) checkRotation(entries chan<- LogArrayEntry, errs chan<- error) {
+	latest, err := findLatestLogFile(t.basePath)
+	if err != nil {
+		errs <- fmt.Errorf("logtail: find latest log file: %w", err)
+		return
+	}
+
+	if latest != t.currentPath {
+		t.drain(entries, errs) // flush whatever is left of the old file first
+		if err := t.switchTo(latest, true); err != nil {
+			errs <- err
+		}
+	}
+}
+
+// drain reads every complete new line from the current file, handling
+// truncation (the file shrank since we last read it) by reopening from 0.
+func (t *Tailer) drain(entries chan<- LogArrayEntry, errs chan<- error) {
+	if t.file == nil {
+		return
+	}
+
+	if fi, err := t.file.Stat(); err == nil && fi.Size() < t.offset {
+		if err := t.switchTo(t.currentPath, false); err != nil {
+			errs <- err
+			return
+		}
+	}
+
+	for {
+		line, err := t.reader.ReadBytes('\n')
+		t.offset += int64(len(line))
+
+		if err == nil {
+			// ReadBytes only returns a nil error once it has found the
+			// delimiter, so line is always a complete line here (though it
+			// may still fail to decode).
+			trimmed := trimNewline(line)
+			if len(trimmed) > 0 {
+				entry, perr := parseLine(trimmed)
+				if perr != nil {
+					errs <- fmt.Errorf("logtail: decode line: %w", perr)
+				} else {
+					entries <- entry
+				}
+			}
+			continue
+		}
+
+		if err != io.EOF {
+			errs <- fmt.Errorf("logtail: read %s: %w", t.currentPath, err)
+		}
+		// The writer hasn't flushed the rest of this line yet: rewind the
+		// offset so the next drain re-reads it once it's complete, rather
+		// than decoding the partial bytes we got.
+		if len(line) > 0 {
+			t.offset -= int64(len(line))
+			t.file.Seek(t.offset, io.SeekStart)
+			t.reader = bufio.NewReader(t.file)
+		}
+		return
+	}
+}
+
+func trimNewline(line []byte) []byte {
+	n := len(line)
+	for n > 0 && (line[n-1] == '\n' || line[n-1] == '\r') {
+		n--
+	}
+	return line[:n]
+}
+
+func dirOf(path string) string {
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i] == '/' {
+			return path[:i]
+		}
+	}
+	return "."
+}
@@ -0,0 +1,85 @@
+package logtail
+
+import "encoding/json"
+
+// ValidatorData is the decoded `validator_data` half of a log line. The
+// node emits `heartbeat_statuses` as an array of [address, status] pairs
+// rather than an object, so it needs the custom UnmarshalJSON below.
+type ValidatorData struct {
+	HomeValidator              string                     `json:"home_validator"`
+	ValidatorsMissingHeartbeat []string                   `json:"validators_missing_heartbeat"`
+	HeartbeatStatuses          map[string]HeartbeatStatus `json:"heartbeat_statuses"`
+}
+
+type HeartbeatStatus struct {
+	SinceLastSuccess float64  `json:"since_last_success"`
+	LastAckDuration  *float64 `json:"last_ack_duration"`
+}
+
+// LogArrayEntry is one decoded `[timestamp, validator_data]` line from the
+// consensus log.
+type LogArrayEntry struct {
+	Timestamp string        `json:"timestamp"`
+	Validator ValidatorData `json:"validator_data"`
+}
+
+func (vd *ValidatorData) UnmarshalJSON(data []byte) error {
+	// Create a temporary struct for the standard fields
+	type Alias ValidatorData
+	aux := &struct {
+		HeartbeatStatuses [][]interface{} `json:"heartbeat_statuses"`
+		*Alias
+	}{
+		Alias: (*Alias)(vd),
+	}
+
+	// Unmarshal the JSON into the auxiliary structure
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	// Convert HeartbeatStatuses from array to map
+	vd.HeartbeatStatuses = make(map[string]HeartbeatStatus)
+	for _, entry := range aux.HeartbeatStatuses {
+		if len(entry) != 2 {
+			continue
+		}
+
+		key, ok := entry[0].(string)
+		if !ok {
+			continue
+		}
+
+		valueBytes, err := json.Marshal(entry[1])
+		if err != nil {
+			continue
+		}
+
+		var heartbeatStatus HeartbeatStatus
+		if err := json.Unmarshal(valueBytes, &heartbeatStatus); err != nil {
+			continue
+		}
+
+		vd.HeartbeatStatuses[key] = heartbeatStatus
+	}
+
+	return nil
+}
+
+// parseLine decodes a single `[timestamp, validator_data]` JSON line.
+func parseLine(line []byte) (LogArrayEntry, error) {
+	var raw [2]json.RawMessage
+	if err := json.Unmarshal(line, &raw); err != nil {
+		return LogArrayEntry{}, err
+	}
+
+	var entry LogArrayEntry
+	if err := json.Unmarshal(raw[0], &entry.Timestamp); err != nil {
+		return LogArrayEntry{}, err
+	}
+	if err := json.Unmarshal(raw[1], &entry.Validator); err != nil {
+		return LogArrayEntry{}, err
+	}
+
+	return entry, nil
+}
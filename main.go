@@ -2,274 +2,584 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"log"
+	"io"
 	"os"
-	"sort"
-	"strconv"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/BurntSushi/toml"
-	"github.com/PagerDuty/go-pagerduty"
-	"github.com/slack-go/slack"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+
+	"github.com/DaveWK/hlmon/alertstate"
+	"github.com/DaveWK/hlmon/logtail"
+	"github.com/DaveWK/hlmon/metricsrv"
+	"github.com/DaveWK/hlmon/notify"
+	"github.com/DaveWK/hlmon/rules"
 )
 
+const configPath = "config.toml"
+
 type Config struct {
-	SlackToken         string `toml:"slack_token"`
-	SlackChannel       string `toml:"slack_channel"`
-	PagerDutyAPIKey    string `toml:"pagerduty_api_key"`
-	PagerDutyServiceID string `toml:"pagerduty_service_id"`
-	BasePath           string `toml:"base_path"`
-	ValidatorAddress   string `toml:"validator_address"`
-	CheckInterval      int    `toml:"check_interval"`
+	BasePath   string              `toml:"base_path"`
+	WatchAll   bool                `toml:"watch_all"`
+	Validators []ValidatorConfig   `toml:"validators"`
+	Notifiers  []notify.SinkConfig `toml:"notifiers"`
+	Rules      []rules.Rule        `toml:"rules"`
+
+	// For and ResolveAfter are the hysteresis defaults applied to any rule
+	// that doesn't set its own; RepeatInterval applies to every rule, since
+	// a rule has no repeat_interval of its own. ResolveAfter requires
+	// the condition to stay clear for that long before a FIRING alert is
+	// reported resolved, preventing a single clean tick from flapping it.
+	For            string `toml:"for"`
+	RepeatInterval string `toml:"repeat_interval"`
+	ResolveAfter   string `toml:"resolve_after"`
+
+	// SinceLastSuccessThreshold and LastAckDurationThreshold are the
+	// since_last_success_threshold/last_ack_duration_threshold values
+	// exposed to rules for any watched validator that doesn't set its own
+	// override in `[[validators]]`. Zero falls back to 40s / 0.02s, the
+	// thresholds defaultRules() alerts on out of the box.
+	SinceLastSuccessThreshold float64 `toml:"since_last_success_threshold"`
+	LastAckDurationThreshold  float64 `toml:"last_ack_duration_threshold"`
+
+	// HomeValidator, if set, is the address this node expects to see as
+	// `home_validator` in every log entry; a mismatch usually means the
+	// node is pointed at the wrong validator set.
+	HomeValidator string `toml:"home_validator"`
+
+	// QuorumLostFraction fires the "quorum_lost" rule once more than this
+	// fraction of the known validator set is missing heartbeats.
+	QuorumLostFraction float64 `toml:"quorum_lost_fraction"`
+
+	StateDBPath string `toml:"state_db_path"`
+	MetricsAddr string `toml:"metrics_addr"`
+	ReadyAfter  string `toml:"ready_after"`
+
+	// LogLevel is one of zerolog's level names (trace, debug, info, warn,
+	// error), defaulting to "info". LogFormat is "console" for a
+	// human-readable development log, or "json" (the default) for the
+	// structured output log aggregators expect.
+	LogLevel  string `toml:"log_level"`
+	LogFormat string `toml:"log_format"`
+}
+
+// ValidatorConfig is one entry of the `[[validators]]` array: a validator to
+// watch when not running in watch_all mode, plus optional per-validator
+// threshold overrides (e.g. a tighter one for your home validator than for
+// peers). Either threshold left at zero falls back to the matching
+// top-level Config default.
+type ValidatorConfig struct {
+	Address                   string  `toml:"address"`
+	SinceLastSuccessThreshold float64 `toml:"since_last_success_threshold"`
+	LastAckDurationThreshold  float64 `toml:"last_ack_duration_threshold"`
 }
 
-type ValidatorData struct {
-	HomeValidator              string                     `json:"home_validator"`
-	ValidatorsMissingHeartbeat []string                   `json:"validators_missing_heartbeat"`
-	HeartbeatStatuses          map[string]HeartbeatStatus `json:"heartbeat_statuses"`
+// resolvedThresholds is the fully-resolved (override-or-default) threshold
+// pair for one watched validator, exposed to rules via Env so an expression
+// can reference "this validator's threshold" without hardcoding an address.
+type resolvedThresholds struct {
+	sinceLastSuccess float64
+	lastAckDuration  float64
 }
 
-type HeartbeatStatus struct {
-	SinceLastSuccess float64  `json:"since_last_success"`
-	LastAckDuration  *float64 `json:"last_ack_duration"`
+// resolveThresholds applies vc's overrides on top of config's defaults,
+// falling back to the hardcoded defaults defaultRules() alerts on if
+// neither sets a value.
+func resolveThresholds(vc ValidatorConfig, config Config) resolvedThresholds {
+	since := vc.SinceLastSuccessThreshold
+	if since == 0 {
+		since = config.SinceLastSuccessThreshold
+	}
+	if since == 0 {
+		since = 40
+	}
+
+	lastAck := vc.LastAckDurationThreshold
+	if lastAck == 0 {
+		lastAck = config.LastAckDurationThreshold
+	}
+	if lastAck == 0 {
+		lastAck = 0.02
+	}
+
+	return resolvedThresholds{sinceLastSuccess: since, lastAckDuration: lastAck}
 }
 
-type LogArrayEntry struct {
-	Timestamp string        `json:"timestamp"`
-	Validator ValidatorData `json:"validator_data"`
+// defaultRules is used when config.toml has no `[[rules]]` entries, so a
+// fresh install still alerts on stalled heartbeats out of the box.
+func defaultRules() []rules.Rule {
+	return []rules.Rule{{
+		Name:     "heartbeat_stalled",
+		Expr:     "since_last_success > since_last_success_threshold || (last_ack_duration != nil && last_ack_duration > last_ack_duration_threshold)",
+		Severity: "critical",
+	}}
 }
 
-func sendSlackAlert(api *slack.Client, channel, message string) {
-	_, _, err := api.PostMessage(
-		channel,
-		slack.MsgOptionText(message, false),
-	)
+func main() {
+	config, err := loadConfig(configPath)
 	if err != nil {
-		log.Printf("Slack API Error: %s\n", err)
+		log.Fatal().Err(err).Msg("error loading configuration")
 	}
-}
 
-func sendPagerDutyAlert(routingKey, description string) {
-	event := pagerduty.V2Event{
-		RoutingKey: routingKey,
-		Action:     "trigger",
-		Payload: &pagerduty.V2Payload{
-			Summary:   description,
-			Source:    "validator-monitoring-script",
-			Severity:  "critical",
-			Component: "Validator Monitoring",
-		},
+	configureLogging(config)
+
+	sinks, err := notify.BuildAll(config.Notifiers)
+	if err != nil {
+		log.Fatal().Err(err).Msg("error configuring notifiers")
+	}
+
+	ruleSet := config.Rules
+	if len(ruleSet) == 0 {
+		ruleSet = defaultRules()
 	}
-	_, err := pagerduty.ManageEventWithContext(context.Background(), event)
+	engine, err := rules.NewEngine(ruleSet)
 	if err != nil {
-		log.Printf("PagerDuty API Error: %s\n", err)
+		log.Fatal().Err(err).Msg("error compiling rules")
 	}
-}
-func (vd *ValidatorData) UnmarshalJSON(data []byte) error {
-	// Create a temporary struct for the standard fields
-	type Alias ValidatorData
-	aux := &struct {
-		HeartbeatStatuses [][]interface{} `json:"heartbeat_statuses"`
-		*Alias
-	}{
-		Alias: (*Alias)(vd),
+
+	defaultThresholds := resolveThresholds(ValidatorConfig{}, config)
+	perValidatorThresholds := make(map[string]resolvedThresholds, len(config.Validators))
+	for _, vc := range config.Validators {
+		perValidatorThresholds[vc.Address] = resolveThresholds(vc, config)
 	}
 
-	// Unmarshal the JSON into the auxiliary structure
-	if err := json.Unmarshal(data, &aux); err != nil {
-		return err
+	defaultFor, err := parseDurationOrDefault(config.For, 0)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid `for` duration")
+	}
+	defaultRepeat, err := parseDurationOrDefault(config.RepeatInterval, 0)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid `repeat_interval` duration")
+	}
+	defaultResolveAfter, err := parseDurationOrDefault(config.ResolveAfter, 0)
+	if err != nil {
+		log.Fatal().Err(err).Msg("invalid `resolve_after` duration")
 	}
 
-	// Convert HeartbeatStatuses from array to map
-	vd.HeartbeatStatuses = make(map[string]HeartbeatStatus)
-	for _, entry := range aux.HeartbeatStatuses {
-		if len(entry) != 2 {
-			continue
+	var store alertstate.Store
+	if config.StateDBPath != "" {
+		boltStore, err := alertstate.OpenBoltStore(config.StateDBPath)
+		if err != nil {
+			log.Fatal().Err(err).Msg("error opening alert state db")
 		}
+		defer boltStore.Close()
+		store = boltStore
+	}
+	alerts := alertstate.NewManager(store)
 
-		key, ok := entry[0].(string)
-		if !ok {
-			continue
+	var metrics *metricsrv.Server
+	if config.MetricsAddr != "" {
+		readyAfter, err := parseDurationOrDefault(config.ReadyAfter, 2*time.Minute)
+		if err != nil {
+			log.Fatal().Err(err).Msg("invalid `ready_after` duration")
 		}
 
-		valueBytes, err := json.Marshal(entry[1])
+		metrics = metricsrv.NewServer(config.MetricsAddr, readyAfter)
+		go func() {
+			if err := metrics.ListenAndServe(context.Background()); err != nil {
+				log.Error().Err(err).Msg("metrics server error")
+			}
+		}()
+	}
+
+	tailer, err := logtail.NewTailer(config.BasePath, log.Logger)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to start log tailer")
+	}
+	defer tailer.Close()
+
+	entries := make(chan logtail.LogArrayEntry, 64)
+	errs := make(chan error, 64)
+	go tailer.Run(context.Background(), entries, errs)
+
+	mon := &monitor{
+		config:              config,
+		engine:              engine,
+		defaultFor:          defaultFor,
+		defaultRepeat:       defaultRepeat,
+		defaultResolveAfter: defaultResolveAfter,
+		defaultThresholds:   defaultThresholds,
+		thresholds:          perValidatorThresholds,
+		sinks:               sinks,
+		alerts:              alerts,
+		metrics:             metrics,
+	}
+
+	go watchSIGHUP(engine)
+
+	for {
+		select {
+		case logEntry := <-entries:
+			mon.processLogEntry(logEntry)
+		case err := <-errs:
+			event := logTailErrorEvent(err)
+			log.Error().Err(err).Str("event", event).Msg("log tail error")
+			if metrics != nil {
+				if event == "decode_error" {
+					metrics.RecordActivity(time.Now())
+					metrics.IncLogParseErrors()
+				} else {
+					metrics.IncLogTailErrors()
+				}
+			}
+		}
+	}
+}
+
+// configureLogging sets the global zerolog logger's level and writer from
+// config. An unset LogLevel defaults to "info"; an unset or unrecognized
+// LogFormat defaults to JSON output.
+func configureLogging(config Config) {
+	level, err := zerolog.ParseLevel(config.LogLevel)
+	if err != nil || config.LogLevel == "" {
+		level = zerolog.InfoLevel
+	}
+	zerolog.SetGlobalLevel(level)
+
+	var writer io.Writer = os.Stderr
+	if config.LogFormat == "console" {
+		writer = zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339}
+	}
+	log.Logger = zerolog.New(writer).With().Timestamp().Logger()
+}
+
+// loadConfig decodes config.toml from path.
+func loadConfig(path string) (Config, error) {
+	var config Config
+	_, err := toml.DecodeFile(path, &config)
+	return config, err
+}
+
+// watchSIGHUP reloads the rule set from config.toml whenever the process
+// receives SIGHUP, letting operators change `[[rules]]` without a restart.
+// Only the rules are reloaded; every other config field (including the
+// threshold defaults and `[[validators]]` overrides) keeps its startup
+// value. A config that fails to parse or compile is logged and ignored, leaving
+// the previously-loaded rules in effect.
+func watchSIGHUP(engine *rules.Engine) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
+
+	for range sigCh {
+		config, err := loadConfig(configPath)
 		if err != nil {
+			log.Error().Err(err).Str("file", configPath).Msg("sighup: failed to reload config")
 			continue
 		}
 
-		var heartbeatStatus HeartbeatStatus
-		if err := json.Unmarshal(valueBytes, &heartbeatStatus); err != nil {
-			continue
+		ruleSet := config.Rules
+		if len(ruleSet) == 0 {
+			ruleSet = defaultRules()
 		}
 
-		vd.HeartbeatStatuses[key] = heartbeatStatus
+		if err := engine.Reload(ruleSet); err != nil {
+			log.Error().Err(err).Msg("sighup: failed to reload rules")
+			continue
+		}
+		log.Info().Int("rule_count", len(ruleSet)).Str("file", configPath).Msg("sighup: reloaded rules")
 	}
+}
 
-	return nil
+// monitor holds everything processLogEntry needs across ticks: the rule
+// engine, the alert state machine, and where to send/record alerts.
+type monitor struct {
+	config              Config
+	engine              *rules.Engine
+	defaultFor          time.Duration
+	defaultRepeat       time.Duration
+	defaultResolveAfter time.Duration
+	sinks               []notify.Sink
+	alerts              *alertstate.Manager
+	metrics             *metricsrv.Server
+
+	// defaultThresholds and thresholds are the resolved since_last_success
+	// / last_ack_duration thresholds exposed to rules via Env: thresholds
+	// holds per-address overrides from `[[validators]]`, and any address
+	// not present there (including every peer under watch_all) falls back
+	// to defaultThresholds.
+	defaultThresholds resolvedThresholds
+	thresholds        map[string]resolvedThresholds
+
+	// prevMissing is the set of peers reported missing a heartbeat on the
+	// previous tick, so processLogEntry can tell which peers recovered and
+	// need their missing-heartbeat gauge cleared back to 0.
+	prevMissing map[string]struct{}
 }
-func main() {
-	var config Config
-	if _, err := toml.DecodeFile("config.toml", &config); err != nil {
-		log.Fatalf("Error loading configuration: %s\n", err)
+
+// thresholdsFor returns address's resolved thresholds, falling back to
+// defaultThresholds if it has no `[[validators]]` override.
+func (m *monitor) thresholdsFor(address string) resolvedThresholds {
+	if t, ok := m.thresholds[address]; ok {
+		return t
 	}
+	return m.defaultThresholds
+}
 
-	slackClient := slack.New(config.SlackToken)
+func (m *monitor) processLogEntry(logEntry logtail.LogArrayEntry) {
+	log.Debug().Str("timestamp", logEntry.Timestamp).Msg("processing log entry")
 
-	latestLogFile, err := findLatestLogFile(config.BasePath)
+	now, err := time.Parse(time.RFC3339, logEntry.Timestamp)
 	if err != nil {
-		log.Fatalf("Failed to find latest log file: %v", err)
+		now = time.Now()
 	}
-	println(latestLogFile)
-	for {
-		file, err := os.Open(latestLogFile)
-		if err != nil {
-			log.Printf("Error opening log file: %s\n", err)
-			time.Sleep(30 * time.Second)
+
+	validator := logEntry.Validator
+
+	if m.metrics != nil {
+		m.metrics.RecordProcessed(time.Now())
+		m.metrics.RecordActivity(time.Now())
+		m.metrics.ObserveLogFileLag(time.Since(now).Seconds())
+		m.recordMissingHeartbeat(validator.ValidatorsMissingHeartbeat)
+	}
+
+	missingCount := len(validator.ValidatorsMissingHeartbeat)
+	var missingFraction float64
+	if total := len(validator.HeartbeatStatuses); total > 0 {
+		missingFraction = float64(missingCount) / float64(total)
+	}
+
+	for _, address := range m.watchedAddresses(validator) {
+		status, found := validator.HeartbeatStatuses[address]
+		if !found {
+			log.Warn().Str("validator", address).Msg("validator not present in heartbeat_statuses")
 			continue
 		}
 
-		decoder := json.NewDecoder(file)
-		var lastRawEntry json.RawMessage
-		for {
-			var rawEntry json.RawMessage
-			if err := decoder.Decode(&rawEntry); err != nil {
-				if err.Error() == "EOF" {
-					break
-				}
-				log.Printf("Error decoding JSON line: %s\n", err)
-				continue
-			}
-			lastRawEntry = rawEntry
+		if m.metrics != nil {
+			m.metrics.ObserveHeartbeat(address, status.SinceLastSuccess, status.LastAckDuration)
 		}
 
-		if lastRawEntry != nil {
-			log.Printf("Raw JSON content: %s\n", string(lastRawEntry))
-
-			// Attempt to unmarshal as an array containing a timestamp and data
-			var logArray []interface{}
-			if err := json.Unmarshal(lastRawEntry, &logArray); err == nil && len(logArray) == 2 {
-				// Get only the last element
-				timestamp, ok := logArray[0].(string)
-				if !ok {
-					log.Printf("Error: Expected timestamp as first element, got: %v", logArray[0])
-					continue
-				}
+		threshold := m.thresholdsFor(address)
+		env := rules.Env{
+			SinceLastSuccess:          status.SinceLastSuccess,
+			LastAckDuration:           status.LastAckDuration,
+			HomeValidator:             validator.HomeValidator,
+			MissingCount:              missingCount,
+			MissingFraction:           missingFraction,
+			Validator:                 address,
+			SinceLastSuccessThreshold: threshold.sinceLastSuccess,
+			LastAckDurationThreshold:  threshold.lastAckDuration,
+		}
 
-				validatorDataBytes, err := json.Marshal(logArray[1])
-				if err != nil {
-					log.Printf("Error marshaling validator data: %s", err)
-					continue
-				}
+		for _, rule := range m.engine.Rules() {
+			m.evaluateRule(rule, address, env, now, logEntry.Timestamp, status)
+		}
+	}
 
-				var validatorData ValidatorData
-				if err := json.Unmarshal(validatorDataBytes, &validatorData); err != nil {
-					log.Printf("Error decoding validator data: %s", err)
-					continue
-				}
+	m.checkHomeValidatorMismatch(validator, now, logEntry.Timestamp)
+	m.checkQuorumLost(validator, now, logEntry.Timestamp)
+}
 
-				// Create the log entry with the last element
-				logEntry := LogArrayEntry{
-					Timestamp: timestamp,
-					Validator: validatorData,
-				}
+// evaluateRule runs one rule's expression for address and fans out any
+// resulting trigger/repeat/resolve transition.
+func (m *monitor) evaluateRule(rule rules.Rule, address string, env rules.Env, now time.Time, timestamp string, status logtail.HeartbeatStatus) {
+	holds, err := m.engine.Evaluate(rule.Name, env)
+	if err != nil {
+		log.Error().Err(err).Str("validator", address).Str("rule", rule.Name).Msg("rule evaluation error")
+		return
+	}
 
-				// Process the last log entry only
-				processLogEntry(logEntry, slackClient, config)
-			} else {
-				log.Printf("Error: Could not unmarshal JSON line as expected array")
-			}
-		}
+	forDuration, err := parseDurationOrDefault(rule.For, m.defaultFor)
+	if err != nil {
+		log.Warn().Err(err).Str("rule", rule.Name).Msg("rule has invalid `for` duration")
+		forDuration = m.defaultFor
+	}
+	resolveAfter, err := parseDurationOrDefault(rule.ResolveAfter, m.defaultResolveAfter)
+	if err != nil {
+		log.Warn().Err(err).Str("rule", rule.Name).Msg("rule has invalid `resolve_after` duration")
+		resolveAfter = m.defaultResolveAfter
+	}
+
+	message := fmt.Sprintf("[%s] validator %s: since_last_success = %v, last_ack_duration = %v", rule.Name, address, status.SinceLastSuccess, derefOrNil(status.LastAckDuration))
+	m.evaluateAndNotify(address, rule.Name, holds, now, timestamp, message, status, severityOrDefault(rule.Severity), rule.Sinks, forDuration, resolveAfter)
+}
 
-		file.Close()
-		time.Sleep(time.Duration(config.CheckInterval) * time.Second)
+// recordMissingHeartbeat diffs the current tick's missing-peer set against
+// the previous one so the hlmon_validator_missing_heartbeat gauge is reset
+// to 0 for every peer that recovered, rather than staying stuck at 1 once a
+// peer has ever been reported missing.
+func (m *monitor) recordMissingHeartbeat(missing []string) {
+	current := make(map[string]struct{}, len(missing))
+	for _, peer := range missing {
+		current[peer] = struct{}{}
 	}
+
+	var cleared []string
+	for peer := range m.prevMissing {
+		if _, stillMissing := current[peer]; !stillMissing {
+			cleared = append(cleared, peer)
+		}
+	}
+
+	m.metrics.ObserveMissingHeartbeat(missing, cleared)
+	m.prevMissing = current
 }
 
-func processLogEntry(logEntry LogArrayEntry, slackClient *slack.Client, config Config) {
-	log.Printf("Timestamp: %s\n", logEntry.Timestamp)
-	if status, found := logEntry.Validator.HeartbeatStatuses[config.ValidatorAddress]; found {
-		if status.SinceLastSuccess > 40 || (status.LastAckDuration != nil && *status.LastAckDuration > 0.02) || status.LastAckDuration == nil {
-			alertMessage := fmt.Sprintf("Alert for HyperLiq validator %s:\nsince_last_success = %v, last_ack_duration = %v", config.ValidatorAddress, status.SinceLastSuccess, status.LastAckDuration)
-			sendSlackAlert(slackClient, config.SlackChannel, alertMessage)
-			sendPagerDutyAlert(config.PagerDutyAPIKey, alertMessage)
+// watchedAddresses returns every validator address this tick should
+// evaluate: every key in heartbeat_statuses under watch_all, or just the
+// configured `[[validators]]` list otherwise.
+func (m *monitor) watchedAddresses(validator logtail.ValidatorData) []string {
+	if m.config.WatchAll {
+		addresses := make([]string, 0, len(validator.HeartbeatStatuses))
+		for address := range validator.HeartbeatStatuses {
+			addresses = append(addresses, address)
 		}
-	} else if status.SinceLastSuccess <= 0 || *status.LastAckDuration <= 0 {
-		alertMessage := fmt.Sprintf("Alert for HyperLiq validator %s:\nsince_last_success = %v, last_ack_duration = %v", config.ValidatorAddress, status.SinceLastSuccess, status.LastAckDuration)
-		sendSlackAlert(slackClient, config.SlackChannel, alertMessage)
-		sendPagerDutyAlert(config.PagerDutyAPIKey, alertMessage)
+		return addresses
 	}
+
+	addresses := make([]string, 0, len(m.config.Validators))
+	for _, vc := range m.config.Validators {
+		addresses = append(addresses, vc.Address)
+	}
+	return addresses
 }
 
-func findLatestLogFile(basePath string) (string, error) {
-	latestDateDir, err := findLatestDir(basePath)
-	if err != nil {
-		return "", fmt.Errorf("failed to find latest date directory: %w", err)
+// checkHomeValidatorMismatch fires when the node's reported home_validator
+// disagrees with the one this instance expects to be watching.
+func (m *monitor) checkHomeValidatorMismatch(validator logtail.ValidatorData, now time.Time, timestamp string) {
+	if m.config.HomeValidator == "" {
+		return
 	}
 
-	latestLogFile, err := findLatestFile(latestDateDir)
-	if err != nil {
-		return "", fmt.Errorf("failed to find latest log file: %w", err)
+	holds := validator.HomeValidator != m.config.HomeValidator
+	message := fmt.Sprintf("Home validator mismatch: expected %s, node reports %s", m.config.HomeValidator, validator.HomeValidator)
+	m.evaluateAndNotify("home_validator", "home_validator_mismatch", holds, now, timestamp, message, logtail.HeartbeatStatus{}, notify.SeverityCritical, nil, m.defaultFor, m.defaultResolveAfter)
+}
+
+// checkQuorumLost fires when more than QuorumLostFraction of the known
+// validator set is missing heartbeats.
+func (m *monitor) checkQuorumLost(validator logtail.ValidatorData, now time.Time, timestamp string) {
+	if m.config.QuorumLostFraction <= 0 {
+		return
+	}
+
+	total := len(validator.HeartbeatStatuses)
+	if total == 0 {
+		return
 	}
 
-	return latestLogFile, nil
+	missingFraction := float64(len(validator.ValidatorsMissingHeartbeat)) / float64(total)
+	holds := missingFraction > m.config.QuorumLostFraction
+
+	message := fmt.Sprintf("Quorum at risk: %d/%d validators (%.1f%%) missing heartbeats", len(validator.ValidatorsMissingHeartbeat), total, missingFraction*100)
+	m.evaluateAndNotify("network", "quorum_lost", holds, now, timestamp, message, logtail.HeartbeatStatus{}, notify.SeverityCritical, nil, m.defaultFor, m.defaultResolveAfter)
 }
 
-func findLatestDir(basePath string) (string, error) {
-	entries, err := os.ReadDir(basePath)
-	if err != nil {
-		return "", err
+// evaluateAndNotify runs the condition for (validator, rule) through the
+// alert state machine and fans the resulting trigger/repeat/resolve out to
+// the named sinks (or every sink, if names is empty). Transitions that
+// don't warrant a notification (e.g. still PENDING, or already FIRING with
+// no repeat due) are silently absorbed here.
+func (m *monitor) evaluateAndNotify(validator, rule string, holds bool, now time.Time, timestamp, message string, status logtail.HeartbeatStatus, severity notify.Severity, sinkNames []string, forDuration, resolveAfter time.Duration) {
+	key := validator + "/" + rule
+	transition, entry := m.alerts.Evaluate(key, holds, now, forDuration, m.defaultRepeat, resolveAfter)
+
+	switch transition {
+	case alertstate.TransitionTrigger, alertstate.TransitionRepeat:
+		logRuleEvent(validator, rule, timestamp, status)
+		m.fanOutAlert(notify.ActionTrigger, entry.DedupKey, severity, validator, timestamp, message, status, sinkNames)
+	case alertstate.TransitionResolve:
+		m.fanOutAlert(notify.ActionResolve, entry.DedupKey, notify.SeverityInfo, validator, timestamp, fmt.Sprintf("Recovered: %s", message), status, sinkNames)
 	}
+}
 
-	var dirs []string
-	for _, entry := range entries {
-		if entry.IsDir() {
-			dirs = append(dirs, entry.Name())
-		}
+// logRuleEvent emits the structured "rule_fired" event log line for a
+// trigger or repeat transition.
+func logRuleEvent(validator, rule, timestamp string, status logtail.HeartbeatStatus) {
+	entry := log.Info().
+		Str("event", "rule_fired").
+		Str("validator", validator).
+		Str("rule", rule).
+		Str("timestamp", timestamp).
+		Float64("since_last_success", status.SinceLastSuccess)
+	if status.LastAckDuration != nil {
+		entry = entry.Float64("last_ack_duration", *status.LastAckDuration)
 	}
+	entry.Msg("rule fired")
+}
 
-	if len(dirs) == 0 {
-		return "", fmt.Errorf("no directories found in %s", basePath)
+// fanOutAlert delivers alert to every sink named in sinkNames (or every
+// configured sink, if sinkNames is empty), logging (but not aborting on)
+// individual sink failures.
+func (m *monitor) fanOutAlert(action notify.Action, dedupKey string, severity notify.Severity, validator, timestamp, message string, status logtail.HeartbeatStatus, sinkNames []string) {
+	alert := notify.Alert{
+		Severity:  severity,
+		Validator: validator,
+		Timestamp: timestamp,
+		Message:   message,
+		Action:    action,
+		DedupKey:  dedupKey,
+		Status: notify.HeartbeatStatus{
+			SinceLastSuccess: status.SinceLastSuccess,
+			LastAckDuration:  status.LastAckDuration,
+		},
 	}
 
-	latestDir := dirs[0]
-	for _, dir := range dirs {
-		if dir > latestDir {
-			latestDir = dir
+	for _, sink := range m.sinks {
+		if len(sinkNames) > 0 && !contains(sinkNames, sink.Name) {
+			continue
+		}
+
+		if err := sink.Notifier.Notify(context.Background(), alert); err != nil {
+			log.Error().Err(err).Str("event", "sink_error").Str("sink", sink.Name).Str("validator", validator).Msg("notifier error")
+			continue
+		}
+		log.Debug().Str("event", "sink_ok").Str("sink", sink.Name).Str("validator", validator).Msg("notified sink")
+		if m.metrics != nil {
+			m.metrics.IncAlertsFired(sink.Name, string(severity))
 		}
 	}
-
-	return fmt.Sprintf("%s/%s", basePath, latestDir), nil
 }
-func findLatestFile(dirPath string) (string, error) {
-	entries, err := os.ReadDir(dirPath)
-	if err != nil {
-		return "", err
-	}
 
-	var files []string
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			files = append(files, entry.Name())
+func contains(haystack []string, needle string) bool {
+	for _, s := range haystack {
+		if s == needle {
+			return true
 		}
 	}
+	return false
+}
 
-	if len(files) == 0 {
-		return "", fmt.Errorf("no files found in %s", dirPath)
+// derefOrNil dereferences f for use with %v, rather than letting a nil
+// *float64 print as "<nil>" and a non-nil one print as its pointer address.
+func derefOrNil(f *float64) interface{} {
+	if f == nil {
+		return nil
 	}
+	return *f
+}
 
-	// Sort files to ensure correct order
-	sort.Slice(files, func(i, j int) bool {
-		iInt, errI := strconv.Atoi(files[i])
-		jInt, errJ := strconv.Atoi(files[j])
-		if errI == nil && errJ == nil {
-			return iInt < jInt
-		}
-		return files[i] < files[j]
-	})
+// severityOrDefault maps a rule's `severity` string to notify.Severity,
+// defaulting to critical for an unset or unrecognized value.
+func severityOrDefault(s string) notify.Severity {
+	switch notify.Severity(s) {
+	case notify.SeverityInfo, notify.SeverityWarning, notify.SeverityCritical:
+		return notify.Severity(s)
+	default:
+		return notify.SeverityCritical
+	}
+}
 
-	latestFile := files[len(files)-1]
+// parseDurationOrDefault parses s as a time.Duration, returning def if s is
+// empty.
+func parseDurationOrDefault(s string, def time.Duration) (time.Duration, error) {
+	if s == "" {
+		return def, nil
+	}
+	return time.ParseDuration(s)
+}
 
-	return fmt.Sprintf("%s/%s", dirPath, latestFile), nil
+// logTailErrorEvent classifies an error surfaced on logtail's errs channel
+// for the structured "event" log field. Everything that isn't a line-decode
+// failure is reported as a generic tail error.
+func logTailErrorEvent(err error) string {
+	if strings.Contains(err.Error(), "decode line") {
+		return "decode_error"
+	}
+	return "tail_error"
 }
@@ -0,0 +1,112 @@
+// Package metricsrv exposes the data hlmon already computes as a
+// Prometheus /metrics endpoint, plus /healthz and /readyz for use as a
+// Kubernetes or systemd liveness/readiness probe.
+package metricsrv
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics holds every gauge/counter hlmon publishes. It is safe for
+// concurrent use; each Observe/Inc method simply forwards to the
+// corresponding prometheus collector.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	sinceLastSuccess  *prometheus.GaugeVec
+	lastAckDuration   *prometheus.GaugeVec
+	missingHeartbeat  *prometheus.GaugeVec
+	alertsFiredTotal  *prometheus.CounterVec
+	logFileLagSeconds prometheus.Gauge
+	logParseErrors    prometheus.Counter
+	logTailErrors     prometheus.Counter
+}
+
+func newMetrics() *Metrics {
+	registry := prometheus.NewRegistry()
+
+	m := &Metrics{
+		registry: registry,
+		sinceLastSuccess: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "hlmon_validator_since_last_success_seconds",
+			Help: "Seconds since the validator's last successful heartbeat ack.",
+		}, []string{"validator"}),
+		lastAckDuration: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "hlmon_validator_last_ack_duration_seconds",
+			Help: "Duration of the validator's last heartbeat ack, in seconds.",
+		}, []string{"validator"}),
+		missingHeartbeat: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Name: "hlmon_validator_missing_heartbeat",
+			Help: "1 if the peer is currently listed as missing a heartbeat, else 0.",
+		}, []string{"peer"}),
+		alertsFiredTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "hlmon_alerts_fired_total",
+			Help: "Total alerts delivered, by sink and severity.",
+		}, []string{"sink", "severity"}),
+		logFileLagSeconds: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "hlmon_log_file_lag_seconds",
+			Help: "Seconds between the last processed log entry's timestamp and now.",
+		}),
+		logParseErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "hlmon_log_parse_errors_total",
+			Help: "Total JSON log lines that failed to decode.",
+		}),
+		logTailErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "hlmon_log_tail_errors_total",
+			Help: "Total non-decode errors surfaced while tailing the log (watcher errors, rotation lookup failures, file I/O errors).",
+		}),
+	}
+
+	registry.MustRegister(
+		m.sinceLastSuccess,
+		m.lastAckDuration,
+		m.missingHeartbeat,
+		m.alertsFiredTotal,
+		m.logFileLagSeconds,
+		m.logParseErrors,
+		m.logTailErrors,
+	)
+
+	return m
+}
+
+// ObserveHeartbeat records the latest heartbeat gauges for validator.
+func (m *Metrics) ObserveHeartbeat(validator string, sinceLastSuccess float64, lastAckDuration *float64) {
+	m.sinceLastSuccess.WithLabelValues(validator).Set(sinceLastSuccess)
+	if lastAckDuration != nil {
+		m.lastAckDuration.WithLabelValues(validator).Set(*lastAckDuration)
+	}
+}
+
+// ObserveMissingHeartbeat sets the missing-heartbeat gauge for every peer
+// currently reported missing, and clears it for every peer in cleared.
+func (m *Metrics) ObserveMissingHeartbeat(missing, cleared []string) {
+	for _, peer := range missing {
+		m.missingHeartbeat.WithLabelValues(peer).Set(1)
+	}
+	for _, peer := range cleared {
+		m.missingHeartbeat.WithLabelValues(peer).Set(0)
+	}
+}
+
+// IncAlertsFired increments the fired-alert counter for sink/severity.
+func (m *Metrics) IncAlertsFired(sink, severity string) {
+	m.alertsFiredTotal.WithLabelValues(sink, severity).Inc()
+}
+
+// ObserveLogFileLag records how far behind the tailer is from wall clock.
+func (m *Metrics) ObserveLogFileLag(seconds float64) {
+	m.logFileLagSeconds.Set(seconds)
+}
+
+// IncLogParseErrors increments the parse-error counter.
+func (m *Metrics) IncLogParseErrors() {
+	m.logParseErrors.Inc()
+}
+
+// IncLogTailErrors increments the counter for non-decode errors encountered
+// while tailing the log (watcher errors, rotation lookup failures, file I/O
+// errors).
+func (m *Metrics) IncLogTailErrors() {
+	m.logTailErrors.Inc()
+}
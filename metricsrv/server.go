@@ -0,0 +1,146 @@
+package metricsrv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Server hosts /metrics, /healthz, and /readyz over HTTP, and tracks the
+// freshness data those probes report on.
+type Server struct {
+	*Metrics
+
+	httpServer *http.Server
+
+	readyAfter time.Duration
+
+	mu              sync.Mutex
+	lastProcessedAt time.Time
+	lastActivityAt  time.Time
+	startedAt       time.Time
+}
+
+// NewServer builds a Server listening on addr. readyAfter is how long the
+// tailer can go without processing an entry before /readyz reports not
+// ready.
+func NewServer(addr string, readyAfter time.Duration) *Server {
+	s := &Server{
+		Metrics:    newMetrics(),
+		readyAfter: readyAfter,
+		startedAt:  timeNow(),
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(s.registry, promhttp.HandlerOpts{}))
+	mux.HandleFunc("/healthz", s.handleHealthz)
+	mux.HandleFunc("/readyz", s.handleReadyz)
+
+	s.httpServer = &http.Server{Addr: addr, Handler: mux}
+	return s
+}
+
+// ListenAndServe starts the HTTP server. It blocks until ctx is cancelled,
+// at which point it shuts the server down gracefully.
+func (s *Server) ListenAndServe(ctx context.Context) error {
+	errCh := make(chan error, 1)
+	go func() {
+		if err := s.httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errCh <- err
+		}
+	}()
+
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return s.httpServer.Shutdown(shutdownCtx)
+	case err := <-errCh:
+		return err
+	}
+}
+
+// RecordProcessed marks that an entry was successfully processed at t, used
+// by /readyz.
+func (s *Server) RecordProcessed(t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastProcessedAt = t
+}
+
+// RecordActivity marks that the tailer observed the log file move at t
+// (whether or not the line it read decoded cleanly), used by /healthz. A
+// log file that's actively being read but only producing decode errors
+// still counts as alive, even though /readyz would report not-ready.
+func (s *Server) RecordActivity(t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastActivityAt = t
+}
+
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	lastActivityAt := s.lastActivityAt
+	s.mu.Unlock()
+
+	if lastActivityAt.IsZero() {
+		// Give the tailer a grace period to observe its first line before
+		// reporting unhealthy.
+		if timeNow().Sub(s.startedAt) < s.readyAfter {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]string{"status": "starting"})
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintln(w, "no log file activity observed yet")
+		return
+	}
+
+	if age := timeNow().Sub(lastActivityAt); age > s.readyAfter {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintf(w, "no log file activity in %s\n", age)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "ok"})
+}
+
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	lastProcessedAt := s.lastProcessedAt
+	s.mu.Unlock()
+
+	if lastProcessedAt.IsZero() {
+		// Give the tailer a grace period to process its first entry before
+		// reporting not-ready.
+		if timeNow().Sub(s.startedAt) < s.readyAfter {
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]string{"status": "starting"})
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintln(w, "no log entries processed yet")
+		return
+	}
+
+	if age := timeNow().Sub(lastProcessedAt); age > s.readyAfter {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintf(w, "no log entries processed in %s\n", age)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]string{"status": "ready"})
+}
+
+// timeNow exists so tests can be written against a deterministic clock in
+// the future without touching call sites.
+func timeNow() time.Time {
+	return time.Now()
+}
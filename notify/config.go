@@ -0,0 +1,81 @@
+package notify
+
+import "fmt"
+
+// SinkConfig is one entry of the `[[notifiers]]` array in config.toml. Every
+// sink reads whichever fields it needs and ignores the rest, so a single
+// flat struct is used instead of one TOML table per backend.
+type SinkConfig struct {
+	Type string `toml:"type"`
+	Name string `toml:"name"`
+
+	// Slack
+	SlackToken   string `toml:"slack_token"`
+	SlackChannel string `toml:"slack_channel"`
+
+	// PagerDuty
+	PagerDutyRoutingKey string `toml:"pagerduty_routing_key"`
+
+	// Discord / generic webhook
+	WebhookURL string `toml:"webhook_url"`
+
+	// Telegram
+	TelegramBotToken string `toml:"telegram_bot_token"`
+	TelegramChatID   string `toml:"telegram_chat_id"`
+
+	// SMTP email
+	SMTPHost string `toml:"smtp_host"`
+	SMTPPort int    `toml:"smtp_port"`
+	SMTPUser string `toml:"smtp_user"`
+	SMTPPass string `toml:"smtp_pass"`
+	MailFrom string `toml:"mail_from"`
+	MailTo   string `toml:"mail_to"`
+}
+
+// Build constructs the Notifier described by cfg.
+func Build(cfg SinkConfig) (Notifier, error) {
+	switch cfg.Type {
+	case "slack":
+		return NewSlackNotifier(cfg.SlackToken, cfg.SlackChannel), nil
+	case "pagerduty":
+		return NewPagerDutyNotifier(cfg.PagerDutyRoutingKey), nil
+	case "discord":
+		return NewDiscordNotifier(cfg.WebhookURL), nil
+	case "telegram":
+		return NewTelegramNotifier(cfg.TelegramBotToken, cfg.TelegramChatID), nil
+	case "email":
+		return NewEmailNotifier(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUser, cfg.SMTPPass, cfg.MailFrom, cfg.MailTo), nil
+	case "webhook":
+		return NewWebhookNotifier(cfg.WebhookURL), nil
+	default:
+		return nil, fmt.Errorf("notify: unknown sink type %q", cfg.Type)
+	}
+}
+
+// Sink pairs a built Notifier with the name/type it was configured under,
+// so callers can label metrics and log lines per sink.
+type Sink struct {
+	Name     string
+	Type     string
+	Notifier Notifier
+}
+
+// BuildAll constructs every sink in cfgs, returning an error immediately if
+// any entry fails to validate so that a bad config.toml fails fast at
+// startup rather than silently dropping a sink at alert time.
+func BuildAll(cfgs []SinkConfig) ([]Sink, error) {
+	sinks := make([]Sink, 0, len(cfgs))
+	for _, cfg := range cfgs {
+		n, err := Build(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("notify: sink %q: %w", cfg.Name, err)
+		}
+
+		name := cfg.Name
+		if name == "" {
+			name = cfg.Type
+		}
+		sinks = append(sinks, Sink{Name: name, Type: cfg.Type, Notifier: n})
+	}
+	return sinks, nil
+}
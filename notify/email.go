@@ -0,0 +1,38 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/smtp"
+)
+
+// EmailNotifier delivers alerts as plain-text email via SMTP.
+type EmailNotifier struct {
+	host string
+	port int
+	user string
+	pass string
+	from string
+	to   string
+}
+
+func NewEmailNotifier(host string, port int, user, pass, from, to string) *EmailNotifier {
+	return &EmailNotifier{host: host, port: port, user: user, pass: pass, from: from, to: to}
+}
+
+func (e *EmailNotifier) Notify(ctx context.Context, alert Alert) error {
+	addr := fmt.Sprintf("%s:%d", e.host, e.port)
+	subject := fmt.Sprintf("[hlmon] %s alert for %s", alert.Severity, alert.Validator)
+	msg := fmt.Sprintf("To: %s\r\nFrom: %s\r\nSubject: %s\r\n\r\n%s\r\n",
+		e.to, e.from, subject, alert.Message)
+
+	var auth smtp.Auth
+	if e.user != "" {
+		auth = smtp.PlainAuth("", e.user, e.pass, e.host)
+	}
+
+	if err := smtp.SendMail(addr, auth, e.from, []string{e.to}, []byte(msg)); err != nil {
+		return fmt.Errorf("email: %w", err)
+	}
+	return nil
+}
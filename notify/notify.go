@@ -0,0 +1,53 @@
+// Package notify provides a pluggable sink abstraction for delivering
+// validator health alerts to external services (Slack, PagerDuty, Discord,
+// Telegram, email, or a generic webhook).
+package notify
+
+import "context"
+
+// Severity classifies how urgently an Alert should be treated by a sink.
+type Severity string
+
+const (
+	SeverityInfo     Severity = "info"
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+// HeartbeatStatus mirrors the shape of a single validator's heartbeat entry
+// as decoded from the consensus log. It is duplicated here (rather than
+// imported from main) so that notify has no dependency on the top-level
+// package.
+type HeartbeatStatus struct {
+	SinceLastSuccess float64
+	LastAckDuration  *float64
+}
+
+// Action distinguishes a new/ongoing alert from its eventual recovery, so
+// sinks that support incident lifecycles (PagerDuty) can trigger and
+// resolve the same incident instead of opening a new one each tick.
+type Action string
+
+const (
+	ActionTrigger Action = "trigger"
+	ActionResolve Action = "resolve"
+)
+
+// Alert is the payload handed to every configured Notifier.
+type Alert struct {
+	Severity  Severity
+	Validator string
+	Timestamp string
+	Message   string
+	Status    HeartbeatStatus
+	Action    Action
+	DedupKey  string
+}
+
+// Notifier delivers an Alert to a single external sink. Implementations
+// should treat Notify as best-effort: return an error rather than panicking
+// so that callers can fan out to multiple sinks without one bad sink
+// aborting the rest.
+type Notifier interface {
+	Notify(ctx context.Context, alert Alert) error
+}
@@ -0,0 +1,43 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PagerDuty/go-pagerduty"
+)
+
+// PagerDutyNotifier triggers PagerDuty Events API v2 incidents.
+type PagerDutyNotifier struct {
+	routingKey string
+}
+
+func NewPagerDutyNotifier(routingKey string) *PagerDutyNotifier {
+	return &PagerDutyNotifier{routingKey: routingKey}
+}
+
+func (p *PagerDutyNotifier) Notify(ctx context.Context, alert Alert) error {
+	action := string(alert.Action)
+	if action == "" {
+		action = "trigger"
+	}
+
+	event := pagerduty.V2Event{
+		RoutingKey: p.routingKey,
+		Action:     action,
+		DedupKey:   alert.DedupKey,
+	}
+	if action == "trigger" {
+		event.Payload = &pagerduty.V2Payload{
+			Summary:   alert.Message,
+			Source:    alert.Validator,
+			Severity:  string(alert.Severity),
+			Component: "Validator Monitoring",
+		}
+	}
+
+	if _, err := pagerduty.ManageEventWithContext(ctx, event); err != nil {
+		return fmt.Errorf("pagerduty: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/slack-go/slack"
+)
+
+// SlackNotifier posts alerts to a Slack channel via a bot token.
+type SlackNotifier struct {
+	client  *slack.Client
+	channel string
+}
+
+func NewSlackNotifier(token, channel string) *SlackNotifier {
+	return &SlackNotifier{client: slack.New(token), channel: channel}
+}
+
+func (s *SlackNotifier) Notify(ctx context.Context, alert Alert) error {
+	text := fmt.Sprintf("[%s] %s", alert.Severity, alert.Message)
+	if alert.Action == ActionResolve {
+		text = fmt.Sprintf(":white_check_mark: RESOLVED: %s", alert.Message)
+	}
+
+	_, _, err := s.client.PostMessageContext(
+		ctx,
+		s.channel,
+		slack.MsgOptionText(text, false),
+	)
+	if err != nil {
+		return fmt.Errorf("slack: %w", err)
+	}
+	return nil
+}
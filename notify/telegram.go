@@ -0,0 +1,44 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// TelegramNotifier delivers alerts as chat messages via the Telegram Bot API.
+type TelegramNotifier struct {
+	botToken string
+	chatID   string
+	client   *http.Client
+}
+
+func NewTelegramNotifier(botToken, chatID string) *TelegramNotifier {
+	return &TelegramNotifier{botToken: botToken, chatID: chatID, client: http.DefaultClient}
+}
+
+func (t *TelegramNotifier) Notify(ctx context.Context, alert Alert) error {
+	endpoint := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", t.botToken)
+	form := url.Values{
+		"chat_id": {t.chatID},
+		"text":    {fmt.Sprintf("[%s] %s", alert.Severity, alert.Message)},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("telegram: %w", err)
+	}
+	req.URL.RawQuery = form.Encode()
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("telegram: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("telegram: unexpected status %s", resp.Status)
+	}
+	return nil
+}
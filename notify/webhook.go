@@ -0,0 +1,82 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// WebhookNotifier POSTs the Alert as JSON to an arbitrary URL. It is the
+// fallback sink for services that don't warrant a dedicated implementation.
+type WebhookNotifier struct {
+	url    string
+	client *http.Client
+}
+
+func NewWebhookNotifier(url string) *WebhookNotifier {
+	return &WebhookNotifier{url: url, client: http.DefaultClient}
+}
+
+func (w *WebhookNotifier) Notify(ctx context.Context, alert Alert) error {
+	body, err := json.Marshal(alert)
+	if err != nil {
+		return fmt.Errorf("webhook: marshal alert: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook: unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+// DiscordNotifier posts to a Discord incoming webhook URL.
+type DiscordNotifier struct {
+	webhook *WebhookNotifier
+}
+
+func NewDiscordNotifier(webhookURL string) *DiscordNotifier {
+	return &DiscordNotifier{webhook: NewWebhookNotifier(webhookURL)}
+}
+
+func (d *DiscordNotifier) Notify(ctx context.Context, alert Alert) error {
+	payload := struct {
+		Content string `json:"content"`
+	}{
+		Content: fmt.Sprintf("**[%s]** %s", alert.Severity, alert.Message),
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("discord: marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, d.webhook.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("discord: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := d.webhook.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("discord: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("discord: unexpected status %s", resp.Status)
+	}
+	return nil
+}
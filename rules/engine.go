@@ -0,0 +1,83 @@
+package rules
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+)
+
+// Engine holds a compiled, hot-reloadable set of Rules. Expressions are
+// compiled once (at load and on Reload) rather than per-tick, so a typo in
+// config.toml is caught immediately instead of on the next alert.
+type Engine struct {
+	mu       sync.RWMutex
+	rules    []Rule
+	programs map[string]*vm.Program
+}
+
+// NewEngine compiles every rule in rs, returning an error (rather than a
+// partially-usable Engine) if any expression fails to parse.
+func NewEngine(rs []Rule) (*Engine, error) {
+	programs, err := compile(rs)
+	if err != nil {
+		return nil, err
+	}
+	return &Engine{rules: rs, programs: programs}, nil
+}
+
+func compile(rs []Rule) (map[string]*vm.Program, error) {
+	programs := make(map[string]*vm.Program, len(rs))
+	for _, r := range rs {
+		program, err := expr.Compile(r.Expr, expr.Env(Env{}), expr.AsBool())
+		if err != nil {
+			return nil, fmt.Errorf("rules: rule %q: %w", r.Name, err)
+		}
+		programs[r.Name] = program
+	}
+	return programs, nil
+}
+
+// Rules returns a snapshot of the currently-loaded rules.
+func (e *Engine) Rules() []Rule {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.rules
+}
+
+// Evaluate runs the named rule's expression against env.
+func (e *Engine) Evaluate(name string, env Env) (bool, error) {
+	e.mu.RLock()
+	program, ok := e.programs[name]
+	e.mu.RUnlock()
+	if !ok {
+		return false, fmt.Errorf("rules: unknown rule %q", name)
+	}
+
+	result, err := expr.Run(program, env)
+	if err != nil {
+		return false, fmt.Errorf("rules: evaluate %q: %w", name, err)
+	}
+
+	holds, ok := result.(bool)
+	if !ok {
+		return false, fmt.Errorf("rules: rule %q did not evaluate to a bool", name)
+	}
+	return holds, nil
+}
+
+// Reload recompiles rs and, if every expression is valid, atomically swaps
+// it in. On error the Engine keeps running with its previous rule set.
+func (e *Engine) Reload(rs []Rule) error {
+	programs, err := compile(rs)
+	if err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	e.rules = rs
+	e.programs = programs
+	e.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,47 @@
+package rules
+
+import "testing"
+
+func TestEvaluateNilLastAckDuration(t *testing.T) {
+	engine, err := NewEngine([]Rule{
+		{
+			Name: "ack-too-slow",
+			Expr: "last_ack_duration != nil && last_ack_duration > last_ack_duration_threshold",
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+
+	env := Env{
+		LastAckDuration:          nil,
+		LastAckDurationThreshold: 0.02,
+	}
+	holds, err := engine.Evaluate("ack-too-slow", env)
+	if err != nil {
+		t.Fatalf("Evaluate with nil LastAckDuration: %v", err)
+	}
+	if holds {
+		t.Fatalf("Evaluate with nil LastAckDuration: got holds=true, want false")
+	}
+
+	ackDuration := 0.5
+	env.LastAckDuration = &ackDuration
+	holds, err = engine.Evaluate("ack-too-slow", env)
+	if err != nil {
+		t.Fatalf("Evaluate with non-nil LastAckDuration: %v", err)
+	}
+	if !holds {
+		t.Fatalf("Evaluate with non-nil LastAckDuration over threshold: got holds=false, want true")
+	}
+}
+
+func TestEvaluateUnknownRule(t *testing.T) {
+	engine, err := NewEngine(nil)
+	if err != nil {
+		t.Fatalf("NewEngine: %v", err)
+	}
+	if _, err := engine.Evaluate("missing", Env{}); err == nil {
+		t.Fatal("Evaluate with unknown rule name: got nil error, want error")
+	}
+}
@@ -0,0 +1,31 @@
+// Package rules lets operators declare alert conditions in config.toml as
+// small boolean expressions instead of hardcoding thresholds in Go.
+package rules
+
+// Rule is one `[[rules]]` entry: a named boolean expression plus the
+// metadata needed to act on it once it fires.
+type Rule struct {
+	Name         string   `toml:"name"`
+	Expr         string   `toml:"expr"`
+	For          string   `toml:"for"`
+	ResolveAfter string   `toml:"resolve_after"`
+	Severity     string   `toml:"severity"`
+	Sinks        []string `toml:"sinks"`
+}
+
+// Env is the variable set exposed to every rule expression.
+type Env struct {
+	SinceLastSuccess float64  `expr:"since_last_success"`
+	LastAckDuration  *float64 `expr:"last_ack_duration"`
+	HomeValidator    string   `expr:"home_validator"`
+	MissingCount     int      `expr:"missing_count"`
+	MissingFraction  float64  `expr:"missing_fraction"`
+	Validator        string   `expr:"validator"`
+
+	// SinceLastSuccessThreshold and LastAckDurationThreshold are this
+	// validator's resolved (override-or-default) thresholds, letting a rule
+	// compare against a per-validator limit (e.g. a tighter one for the
+	// home validator) without hardcoding any validator address.
+	SinceLastSuccessThreshold float64 `expr:"since_last_success_threshold"`
+	LastAckDurationThreshold  float64 `expr:"last_ack_duration_threshold"`
+}